@@ -8,7 +8,11 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
@@ -47,9 +51,29 @@ var (
 	help               = flag.Bool("help", false, "print help on available options")
 	analysisMode       = utils.CommaSeparatedFlags("mode", []string{"static", "dynamic"},
 		"list of analysis modes to run, separated by commas. Use -list-modes to see available options")
-	taskID = flag.String("task-id", "", "task ID for completion signaling (or use TASK_ID env)")
-    internalAPIToken = flag.String("internal-api-token", "", "internal API token for completion signaling (or use INTERNAL_API_TOKEN env)")
-	apiURL = flag.String("api-url", "", "API URL for completion signaling (or use API_URL env)")
+	taskID           = flag.String("task-id", "", "task ID for completion signaling (or use TASK_ID env)")
+	internalAPIToken = flag.String("internal-api-token", "", "internal API token for completion signaling (or use INTERNAL_API_TOKEN env)")
+	apiURL           = flag.String("api-url", "", "API URL for completion signaling (or use API_URL env)")
+
+	reporterBackend        = flag.String("reporter-backend", "http", "completion signaling backend: http, redis, kinesis, kafka, or none (or use REPORTER_BACKEND env)")
+	reporterMirrorBackends = flag.String("reporter-mirror-backends", "", "comma-separated additional reporter backends to mirror events to alongside -reporter-backend, e.g. \"redis,kafka\" (or use REPORTER_MIRROR_BACKENDS env)")
+
+	reporterRedisAddr         = flag.String("reporter-redis-addr", "", "redis address for the redis reporter backend, e.g. localhost:6379 (or use REPORTER_REDIS_ADDR env)")
+	reporterRedisPassword     = flag.String("reporter-redis-password", "", "redis password for the redis reporter backend (or use REPORTER_REDIS_PASSWORD env)")
+	reporterRedisStreamPrefix = flag.String("reporter-redis-stream-prefix", "", "prefix for the redis stream name used by the redis reporter backend")
+
+	reporterKinesisStreamName = flag.String("reporter-kinesis-stream", "", "Kinesis stream name for the kinesis reporter backend (or use REPORTER_KINESIS_STREAM env)")
+
+	reporterKafkaBrokers = flag.String("reporter-kafka-brokers", "", "comma-separated Kafka broker addresses for the kafka reporter backend (or use REPORTER_KAFKA_BROKERS env)")
+	reporterKafkaTopic   = flag.String("reporter-kafka-topic", "", "Kafka topic for the kafka reporter backend (or use REPORTER_KAFKA_TOPIC env)")
+
+	reporterBacklogSize = flag.Int("reporter-backlog-size", 100, "max buffered events for streaming reporter backends (kinesis, kafka) before the oldest is dropped")
+
+	reporterClientCert = flag.String("reporter-client-cert", "", "client certificate (PEM) for mTLS to the completion reporter callback (or use REPORTER_CLIENT_CERT env)")
+	reporterClientKey  = flag.String("reporter-client-key", "", "client private key (PEM) for mTLS to the completion reporter callback (or use REPORTER_CLIENT_KEY env)")
+	reporterCACert     = flag.String("reporter-ca-cert", "", "CA certificate (PEM) used to verify the completion reporter callback (or use REPORTER_CA_CERT env)")
+
+	shutdownGrace = flag.Duration("shutdown-grace", 30*time.Second, "max time finalization (flushing partial results, reporting abort) may take on SIGTERM/SIGINT before returning anyway")
 )
 
 // usageError wraps an error, to signal that the error arises from incorrect user input.
@@ -83,6 +107,123 @@ func makeResultStores() worker.ResultStores {
 	return rs
 }
 
+// reporterBackendName resolves the selected completion reporter backend
+// from -reporter-backend (or REPORTER_BACKEND). It's split out from
+// reporterConfig so run() can decide which of -api-url/-internal-api-token
+// are actually required before the rest of the reporter config is built.
+func reporterBackendName() string {
+	backend := *reporterBackend
+	if envBackend := os.Getenv("REPORTER_BACKEND"); envBackend != "" {
+		backend = envBackend
+	}
+	return backend
+}
+
+// reporterConfig builds the worker.ReporterConfig for the completion
+// reporter backend selected via -reporter-backend (or REPORTER_BACKEND),
+// pulling in the per-backend flags/env vars for whichever backend is
+// selected, and wires any backends named in -reporter-mirror-backends (or
+// REPORTER_MIRROR_BACKENDS) into Mirror so operators can fan events out to
+// more than one backend at once, e.g. an HTTP callback plus a Redis stream
+// for audit.
+func reporterConfig(apiURL, internalAPIToken, taskID, ecosystemName string, idle *worker.IdleTracker) worker.ReporterConfig {
+	cfg := singleReporterConfig(reporterBackendName(), apiURL, internalAPIToken, taskID, ecosystemName, idle)
+
+	mirrorBackends := *reporterMirrorBackends
+	if envMirrors := os.Getenv("REPORTER_MIRROR_BACKENDS"); envMirrors != "" {
+		mirrorBackends = envMirrors
+	}
+	for _, mirrorBackend := range splitAndTrim(mirrorBackends, ",") {
+		cfg.Mirror = append(cfg.Mirror, singleReporterConfig(mirrorBackend, apiURL, internalAPIToken, taskID, ecosystemName, idle))
+	}
+
+	return cfg
+}
+
+// singleReporterConfig builds the worker.ReporterConfig for a single named
+// backend, without Mirror populated, reusing the same per-backend
+// flags/env vars regardless of whether backend is the primary reporter or
+// one of its mirrors.
+func singleReporterConfig(backend, apiURL, internalAPIToken, taskID, ecosystemName string, idle *worker.IdleTracker) worker.ReporterConfig {
+	clientCert := *reporterClientCert
+	if clientCert == "" {
+		clientCert = os.Getenv("REPORTER_CLIENT_CERT")
+	}
+	clientKey := *reporterClientKey
+	if clientKey == "" {
+		clientKey = os.Getenv("REPORTER_CLIENT_KEY")
+	}
+	caCert := *reporterCACert
+	if caCert == "" {
+		caCert = os.Getenv("REPORTER_CA_CERT")
+	}
+
+	redisAddr := *reporterRedisAddr
+	if redisAddr == "" {
+		redisAddr = os.Getenv("REPORTER_REDIS_ADDR")
+	}
+	redisPassword := *reporterRedisPassword
+	if redisPassword == "" {
+		redisPassword = os.Getenv("REPORTER_REDIS_PASSWORD")
+	}
+
+	kinesisStream := *reporterKinesisStreamName
+	if kinesisStream == "" {
+		kinesisStream = os.Getenv("REPORTER_KINESIS_STREAM")
+	}
+
+	kafkaBrokers := *reporterKafkaBrokers
+	if kafkaBrokers == "" {
+		kafkaBrokers = os.Getenv("REPORTER_KAFKA_BROKERS")
+	}
+	kafkaTopic := *reporterKafkaTopic
+	if kafkaTopic == "" {
+		kafkaTopic = os.Getenv("REPORTER_KAFKA_TOPIC")
+	}
+
+	return worker.ReporterConfig{
+		Backend: worker.ReporterBackend(backend),
+		HTTP: worker.HTTPReporterConfig{
+			APIURL:    apiURL,
+			AuthToken: internalAPIToken,
+			TaskID:    taskID,
+			Ecosystem: ecosystemName,
+			MTLS: worker.MTLSConfig{
+				CertFile: clientCert,
+				KeyFile:  clientKey,
+				CAFile:   caCert,
+			},
+			Idle: idle,
+		},
+		Redis: worker.RedisReporterConfig{
+			Addr:         redisAddr,
+			Password:     redisPassword,
+			StreamPrefix: *reporterRedisStreamPrefix,
+		},
+		Kinesis: worker.KinesisReporterConfig{
+			StreamName:  kinesisStream,
+			BacklogSize: *reporterBacklogSize,
+		},
+		Kafka: worker.KafkaReporterConfig{
+			Brokers:     splitAndTrim(kafkaBrokers, ","),
+			Topic:       kafkaTopic,
+			BacklogSize: *reporterBacklogSize,
+		},
+	}
+}
+
+// splitAndTrim splits s on sep, trims whitespace from each part, and drops
+// empty parts. It returns nil for an empty or all-empty input.
+func splitAndTrim(s, sep string) []string {
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
+
 func printAnalysisModes() {
 	fmt.Println("Available analysis modes:")
 	for _, mode := range analysis.AllModes() {
@@ -133,7 +274,61 @@ func makeSandboxOptions() []sandbox.Option {
 	return sbOpts
 }
 
-func dynamicAnalysis(ctx context.Context, pkg *pkgmanager.Pkg, resultStores *worker.ResultStores) {
+// dynamicPhaseName maps the dynamic analysis run phase (install/import/
+// functional) onto the corresponding worker.Phase* lifecycle event name.
+func dynamicPhaseName(lastRunPhase string) string {
+	switch lastRunPhase {
+	case "install":
+		return worker.PhaseDynamicInstall
+	case "import":
+		return worker.PhaseDynamicImport
+	default:
+		return worker.PhaseDynamicFunctional
+	}
+}
+
+// graceDeadline lazily establishes a single deadline, shared across every
+// finalization step in a run, the first time it's asked for a context
+// derived from an already-cancelled parent. Later callers reuse that same
+// deadline rather than each starting a fresh grace window, so the
+// worst-case total time finalization (reporting phases, saving results,
+// flushing the reporter backlog) may take after a shutdown signal is
+// bounded by -shutdown-grace once per run, not once per step.
+type graceDeadline struct {
+	grace time.Duration
+
+	once   sync.Once
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// context returns ctx unchanged while it's still live. Once ctx has been
+// cancelled (e.g. by a shutdown signal), it returns the shared grace-bounded
+// context instead, carrying over ctx's values (e.g. the slog attrs attached
+// via log.ContextWithAttrs) the first time it's derived, so finalization
+// keeps logging with full context during the shutdown grace period.
+func (g *graceDeadline) context(ctx context.Context) context.Context {
+	if ctx.Err() == nil {
+		return ctx
+	}
+	g.once.Do(func() {
+		g.ctx, g.cancel = context.WithTimeout(context.WithoutCancel(ctx), g.grace)
+	})
+	return g.ctx
+}
+
+// stop releases the resources behind the shared deadline, if one was ever
+// established. Safe to call even if context was never invoked.
+func (g *graceDeadline) stop() {
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
+
+// dynamicAnalysis runs dynamic analysis and returns the phase's lifecycle
+// status (worker.StatusCompleted, StatusPartial, or StatusAborted) so the
+// caller can fold it into the task's aggregate final status.
+func dynamicAnalysis(ctx context.Context, pkg *pkgmanager.Pkg, resultStores *worker.ResultStores, reporter worker.CompletionReporter, tc worker.TaskContext, idle *worker.IdleTracker, grace *graceDeadline) string {
 	if !*offline {
 		sandbox.InitNetwork(ctx)
 	}
@@ -144,42 +339,98 @@ func dynamicAnalysis(ctx context.Context, pkg *pkgmanager.Pkg, resultStores *wor
 		sbOpts = append(sbOpts, sandbox.Image(*customSandbox))
 	}
 
+	endSandbox := idle.Begin()
 	result, err := worker.RunDynamicAnalysis(ctx, pkg, sbOpts, *customAnalysisCmd)
+	endSandbox()
 	if err != nil {
+		// RunDynamicAnalysis failed before returning a result, so there's no
+		// LastRunPhase to report against a specific install/import/functional
+		// phase; report the generic "dynamic" phase instead of guessing.
 		slog.ErrorContext(ctx, "Dynamic analysis aborted (run error)", "error", err)
-		return
+		worker.ReportPhase(grace.context(ctx), reporter, tc, worker.PhaseDynamic, worker.StatusAborted,
+			map[string]any{"error": err.Error()})
+		return worker.StatusAborted
 	}
 
+	phase := dynamicPhaseName(string(result.LastRunPhase))
+	phaseStatus := worker.StatusCompleted
+
 	// this is only valid if RunDynamicAnalysis() returns nil err
 	if result.LastStatus != analysis.StatusCompleted {
+		phaseStatus = worker.StatusPartial
 		slog.WarnContext(ctx, "Dynamic analysis phase did not complete successfully",
 			"last_run_phase", string(result.LastRunPhase),
 			"status", string(result.LastStatus))
 	}
 
-	if err := worker.SaveDynamicAnalysisData(ctx, pkg, resultStores, result.Data); err != nil {
+	endUpload := idle.Begin()
+	bytesUploaded, err := worker.SaveDynamicAnalysisData(grace.context(ctx), pkg, resultStores, result.Data)
+	endUpload()
+	if err != nil {
 		slog.ErrorContext(ctx, "Upload error", "error", err)
 	}
+
+	metadata := map[string]any{"last_run_phase": string(result.LastRunPhase), "result_status": string(result.LastStatus)}
+	attachUploadMetadata(metadata, bytesUploaded, err)
+	worker.ReportPhase(grace.context(ctx), reporter, tc, phase, phaseStatus, metadata)
+
+	return phaseStatus
 }
 
-func staticAnalysis(ctx context.Context, pkg *pkgmanager.Pkg, resultStores *worker.ResultStores) {
+// staticAnalysis runs static analysis and returns the phase's lifecycle
+// status (worker.StatusCompleted, StatusPartial, or StatusFailed) so the
+// caller can fold it into the task's aggregate final status.
+func staticAnalysis(ctx context.Context, pkg *pkgmanager.Pkg, resultStores *worker.ResultStores, reporter worker.CompletionReporter, tc worker.TaskContext, idle *worker.IdleTracker, grace *graceDeadline) string {
 	if !*offline {
 		sandbox.InitNetwork(ctx)
 	}
 
 	sbOpts := append(worker.StaticSandboxOptions(), makeSandboxOptions()...)
 
+	worker.ReportPhase(ctx, reporter, tc, worker.PhaseStatic, worker.StatusStarted, nil)
+
+	endSandbox := idle.Begin()
 	data, status, err := worker.RunStaticAnalysis(ctx, pkg, sbOpts, staticanalysis.All)
+	endSandbox()
 	if err != nil {
 		slog.ErrorContext(ctx, "Static analysis aborted", "error", err)
-		return
+		worker.ReportPhase(grace.context(ctx), reporter, tc, worker.PhaseStatic, worker.StatusFailed,
+			map[string]any{"error": err.Error()})
+		return worker.StatusFailed
 	}
 
 	slog.InfoContext(ctx, "Static analysis completed", "status", string(status))
 
-	if err := worker.SaveStaticAnalysisData(ctx, pkg, resultStores, data); err != nil {
+	phaseStatus := worker.StatusCompleted
+	if status != analysis.StatusCompleted {
+		phaseStatus = worker.StatusPartial
+	}
+
+	endUpload := idle.Begin()
+	bytesUploaded, err := worker.SaveStaticAnalysisData(grace.context(ctx), pkg, resultStores, data)
+	endUpload()
+	if err != nil {
 		slog.ErrorContext(ctx, "Upload error", "error", err)
 	}
+
+	metadata := map[string]any{"result_status": string(status)}
+	attachUploadMetadata(metadata, bytesUploaded, err)
+	worker.ReportPhase(grace.context(ctx), reporter, tc, worker.PhaseStatic, phaseStatus, metadata)
+
+	return phaseStatus
+}
+
+// attachUploadMetadata adds the outcome of a SaveDynamicAnalysisData/
+// SaveStaticAnalysisData call to metadata: per-bucket upload byte counts
+// when any were reported, and the upload error (if any) so the backend can
+// tell "phase completed, upload failed" apart from a clean run.
+func attachUploadMetadata(metadata map[string]any, bytesUploaded map[string]int64, uploadErr error) {
+	if len(bytesUploaded) > 0 {
+		metadata["bytes_uploaded"] = bytesUploaded
+	}
+	if uploadErr != nil {
+		metadata["upload_error"] = uploadErr.Error()
+	}
 }
 
 func run() error {
@@ -227,41 +478,68 @@ func run() error {
 		return usagef("missing package name")
 	}
 
-// Get task ID, redis URL, and redis channel from command line flags or environment variables
+	// task-id is optional: lifecycle events are logged structurally via
+	// slog regardless (see worker.deliver), so the signal stays observable
+	// even with no task ID and no reporter backend configured.
 	taskID := *taskID
 	if taskID == "" {
 		taskID = os.Getenv("TASK_ID")
-		if taskID == "" {
-			// raise an error
-			slog.ErrorContext(context.Background(), "Missing task ID", "error", usagef("missing task ID"))
-			return usagef("missing task ID")
-		}
 	}
 	apiURL := *apiURL
 	if apiURL == "" {
 		apiURL = os.Getenv("API_URL")
-		if apiURL == "" {
-			slog.ErrorContext(context.Background(), "Missing API URL", "error", usagef("missing API URL"))
-			return usagef("missing API URL")
-		}
 	}
 	internalAPIToken := *internalAPIToken
 	if internalAPIToken == "" {
 		internalAPIToken = os.Getenv("INTERNAL_API_TOKEN")
+	}
+	// api-url/internal-api-token only matter to the HTTP backend; the other
+	// backends (redis, kinesis, kafka, none) never touch them, so only
+	// require them when the HTTP backend is actually selected.
+	if worker.ReporterBackend(reporterBackendName()) == worker.ReporterHTTP {
+		if apiURL == "" {
+			slog.ErrorContext(context.Background(), "Missing API URL", "error", usagef("missing API URL"))
+			return usagef("missing API URL")
+		}
 		if internalAPIToken == "" {
 			slog.ErrorContext(context.Background(), "Missing internal API token", "error", usagef("missing internal API token"))
 			return usagef("missing internal API token")
 		}
 	}
 
-	// log the task ID, backend URL
+	// Cancel in-progress work (sandboxes, uploads, reporter calls) on
+	// SIGTERM/SIGINT instead of leaving them dangling, e.g. when
+	// Kubernetes terminates the pod mid-run.
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stopSignals()
 
-	// Initialize completion signaler (non-blocking, can be nil if no task_id)
-	var reporter *worker.CompletionReporter
-	if taskID != "" {
-		reporter = worker.NewCompletionReporter(taskID, apiURL, internalAPIToken)
-	}
+	// idle tracks in-flight work units (sandbox boots, result-store
+	// uploads, reporter retries) so shutdown can wait for them to settle
+	// within the grace period instead of cutting them off immediately.
+	idle := worker.NewIdleTracker()
 
+	// grace bounds the total time every finalization step below (reporting
+	// phases, saving results, flushing the reporter backlog) may take once
+	// a shutdown signal arrives, shared across all of them rather than
+	// restarting the window per step.
+	grace := &graceDeadline{grace: *shutdownGrace}
+	defer grace.stop()
+
+	// Initialize the completion reporter for the selected backend.
+	reporter, err := worker.NewCompletionReporter(reporterConfig(apiURL, internalAPIToken, taskID, ecosystem.String(), idle))
+	if err != nil {
+		return usageError{err}
+	}
+	if starter, ok := reporter.(interface{ Start(context.Context) }); ok {
+		starter.Start(context.Background())
+	}
+	if stopper, ok := reporter.(interface{ Stop(context.Context) error }); ok {
+		defer func() {
+			if err := stopper.Stop(grace.context(signalCtx)); err != nil {
+				slog.Error("Failed to flush completion reporter backlog", "error", err)
+			}
+		}()
+	}
 
 	runMode := make(map[analysis.Mode]bool)
 	for _, analysisName := range analysisMode.Values {
@@ -273,7 +551,7 @@ func run() error {
 		runMode[mode] = true
 	}
 
-	ctx := log.ContextWithAttrs(context.Background(),
+	ctx := log.ContextWithAttrs(signalCtx,
 		slog.Any("ecosystem", ecosystem),
 	)
 
@@ -282,11 +560,28 @@ func run() error {
 		slog.String("requested_version", *version),
 	)
 
+	tc := worker.TaskContext{
+		TaskID:    taskID,
+		Ecosystem: ecosystem.String(),
+		Name:      *pkgName,
+		Version:   *version,
+		Attempt:   1,
+	}
+	worker.ReportStarted(ctx, reporter, tc)
+
+	worker.ReportPhase(ctx, reporter, tc, worker.PhaseResolvePackage, worker.StatusStarted, nil)
 	pkg, err := worker.ResolvePkg(manager, *pkgName, *version, *localPkg)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error resolving package", "error", err)
+		worker.ReportPhase(ctx, reporter, tc, worker.PhaseResolvePackage, worker.StatusFailed,
+			map[string]any{"error": err.Error()})
+		worker.ReportFinished(ctx, reporter, tc, worker.StatusFailed, err)
 		return err
 	}
+	worker.ReportPhase(ctx, reporter, tc, worker.PhaseResolvePackage, worker.StatusCompleted, nil)
+
+	tc.Name = pkg.Name()
+	tc.Version = pkg.Version()
 
 	ctx = log.ContextWithAttrs(ctx,
 		slog.String("name", pkg.Name()),
@@ -296,24 +591,47 @@ func run() error {
 	slog.InfoContext(ctx, "Processing resolved package", "package_path", *localPkg)
 	resultStores := makeResultStores()
 
+	overallStatus := worker.StatusCompleted
+
 	if runMode[analysis.Static] {
 		slog.InfoContext(ctx, "Starting static analysis")
-		staticAnalysis(ctx, pkg, &resultStores)
+		overallStatus = worker.WorseStatus(overallStatus, staticAnalysis(ctx, pkg, &resultStores, reporter, tc, idle, grace))
 	}
 
 	// dynamicAnalysis() currently panics on error, so it's last
 	if runMode[analysis.Dynamic] {
 		slog.InfoContext(ctx, "Starting dynamic analysis")
-		dynamicAnalysis(ctx, pkg, &resultStores)
+		overallStatus = worker.WorseStatus(overallStatus, dynamicAnalysis(ctx, pkg, &resultStores, reporter, tc, idle, grace))
+	}
+
+	// Signal completion. If a shutdown signal interrupted analysis above,
+	// wait (up to half the grace period) for in-flight work to settle, then
+	// report the run as aborted rather than whatever partial status the
+	// phases above recorded. finishCtx shares the run's one grace deadline
+	// (rather than context.Background()) so it keeps ctx's slog attrs even
+	// though ctx itself is cancelled.
+	finishErr := error(nil)
+	finishCtx := ctx
+	if signalCtx.Err() != nil {
+		overallStatus = worker.StatusAborted
+		finishErr = fmt.Errorf("analysis interrupted by shutdown signal: %w", signalCtx.Err())
+
+		finishCtx = grace.context(ctx)
+
+		// Only spend half the grace window waiting for in-flight work, so
+		// the ReportFinished call below (still bounded by finishCtx, not
+		// waitCtx) always keeps a real chance to deliver the aborted
+		// status even if something stayed in flight for the whole wait.
+		waitCtx, cancelWait := context.WithTimeout(finishCtx, *shutdownGrace/2)
+		defer cancelWait()
+		if err := idle.WaitIdle(waitCtx); err != nil {
+			slog.WarnContext(ctx, "Shutdown grace period elapsed with work still in flight", "in_flight", idle.InFlight())
+		}
 	}
 
-	// Signal completion
-	analysisStatus := "done"
-	if reporter != nil {
-		if err := reporter.ReportDone(ctx, analysisStatus); err != nil {
-			slog.ErrorContext(ctx, "Failed to report completion", "error", err)
-			// Don't fail the entire run if signaling fails
-		}
+	if err := worker.ReportFinished(finishCtx, reporter, tc, overallStatus, finishErr); err != nil {
+		slog.ErrorContext(ctx, "Failed to report completion", "error", err)
+		// Don't fail the entire run if signaling fails.
 	}
 
 	return nil
@@ -0,0 +1,59 @@
+// Package useragent provides an http.RoundTripper that stamps outgoing
+// requests with a descriptive User-Agent, so backends (package registries,
+// the completion-signaling callback, ...) can attribute traffic to this
+// analyzer rather than seeing Go's generic default.
+package useragent
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Version identifies the analyzer build. It defaults to "dev" and is
+// normally overridden at build time via:
+//
+//	-ldflags "-X github.com/ossf/package-analysis/internal/useragent.Version=..."
+var Version = "dev"
+
+// roundTripper sets a fixed User-Agent on every request it forwards.
+type roundTripper struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+// DefaultRoundTripper wraps next so that every request sent through it
+// carries userAgent as its User-Agent header. If next is nil,
+// http.DefaultTransport is used. If userAgent is empty, a default
+// "packamal-analyze/<Version>" string is used instead. If next is itself a
+// RoundTripper returned by this function, the transport it wraps is used
+// in its place, so its User-Agent does not overwrite userAgent.
+func DefaultRoundTripper(next http.RoundTripper, userAgent string) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if inner, ok := next.(*roundTripper); ok {
+		next = inner.next
+	}
+	if userAgent == "" {
+		userAgent = fmt.Sprintf("packamal-analyze/%s", Version)
+	}
+	return &roundTripper{next: next, userAgent: userAgent}
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	cloned := req.Clone(req.Context())
+	cloned.Header.Set("User-Agent", rt.userAgent)
+	return rt.next.RoundTrip(cloned)
+}
+
+// ReporterUserAgent builds the User-Agent string used for completion
+// reporter callbacks, identifying the task and ecosystem being reported on
+// so backend logs can attribute requests without parsing the body, e.g.
+// "packamal-analyze/1.2.3 (task=abc123; ecosystem=npm)".
+func ReporterUserAgent(taskID, ecosystem string) string {
+	base := fmt.Sprintf("packamal-analyze/%s", Version)
+	if taskID == "" && ecosystem == "" {
+		return base
+	}
+	return fmt.Sprintf("%s (task=%s; ecosystem=%s)", base, taskID, ecosystem)
+}
@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerState is the circuit breaker's current state.
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// BreakerStatus is a point-in-time snapshot of a CircuitBreaker, suitable
+// for exposing via a /healthz-style endpoint or metric.
+type BreakerStatus struct {
+	State               BreakerState
+	OpenSince           time.Time
+	ConsecutiveFailures int
+	Attempts            uint64
+	LastError           string
+}
+
+// CircuitBreaker trips open after FailureThreshold consecutive failures
+// within Window, short-circuiting further calls so a downed backend
+// doesn't block every caller retrying for minutes. After OpenDuration
+// elapses it allows a single half-open probe; success closes the breaker,
+// failure reopens it.
+type CircuitBreaker struct {
+	FailureThreshold int
+	Window           time.Duration
+	OpenDuration     time.Duration
+	Clock            Clock
+
+	mu                  sync.Mutex
+	state               BreakerState
+	consecutiveFailures int
+	lastFailureAt       time.Time
+	openSince           time.Time
+	attempts            uint64
+	lastError           string
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker that opens after
+// failureThreshold consecutive failures within window, and allows a
+// half-open probe after openDuration.
+func NewCircuitBreaker(failureThreshold int, window, openDuration time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: failureThreshold,
+		Window:           window,
+		OpenDuration:     openDuration,
+		state:            BreakerClosed,
+	}
+}
+
+func (b *CircuitBreaker) clock() Clock {
+	if b.Clock != nil {
+		return b.Clock
+	}
+	return realClock{}
+}
+
+// Allow reports whether a call should proceed. It returns false while the
+// breaker is open and OpenDuration has not yet elapsed; once it has, it
+// transitions to half-open and allows exactly one probing call through.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.attempts++
+
+	switch b.state {
+	case BreakerOpen:
+		if b.clock().Now().Sub(b.openSince) >= b.OpenDuration {
+			b.state = BreakerHalfOpen
+			return true
+		}
+		return false
+	case BreakerHalfOpen:
+		// The single probe is already in flight; hold further calls until
+		// it records its outcome.
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the breaker and resets the consecutive failure
+// count.
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.consecutiveFailures = 0
+}
+
+// RecordFailure registers a failed call. If it pushes the consecutive
+// failure count (within Window) to FailureThreshold, or the failure
+// occurred during a half-open probe, the breaker (re)opens.
+func (b *CircuitBreaker) RecordFailure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock().Now()
+	if b.lastFailureAt.IsZero() || now.Sub(b.lastFailureAt) > b.Window {
+		b.consecutiveFailures = 0
+	}
+	b.consecutiveFailures++
+	b.lastFailureAt = now
+	if err != nil {
+		b.lastError = err.Error()
+	}
+
+	if b.state == BreakerHalfOpen || b.consecutiveFailures >= b.FailureThreshold {
+		b.state = BreakerOpen
+		b.openSince = now
+	}
+}
+
+// Status returns a snapshot of the breaker's current state, for exposing
+// via a /healthz-style endpoint.
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return BreakerStatus{
+		State:               b.state,
+		OpenSince:           b.openSince,
+		ConsecutiveFailures: b.consecutiveFailures,
+		Attempts:            b.attempts,
+		LastError:           b.lastError,
+	}
+}
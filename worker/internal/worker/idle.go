@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"sync"
+)
+
+// IdleTracker counts in-flight work units — sandbox boots, result-store
+// uploads, reporter retries, and the like — so a shutdown handler can wait
+// for outstanding work to settle (bounded by its own context/deadline)
+// instead of tearing things down mid-flight. It is safe for concurrent use
+// and lives in internal/worker so commands other than cmd/analyze can share
+// it.
+type IdleTracker struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	n    int
+}
+
+// NewIdleTracker returns a ready-to-use, idle IdleTracker.
+func NewIdleTracker() *IdleTracker {
+	t := &IdleTracker{}
+	t.cond = sync.NewCond(&t.mu)
+	return t
+}
+
+// Begin registers a new in-flight work unit and returns a func that must be
+// called exactly once when that work completes.
+func (t *IdleTracker) Begin() func() {
+	if t == nil {
+		return func() {}
+	}
+
+	t.mu.Lock()
+	t.n++
+	t.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.mu.Lock()
+			t.n--
+			if t.n == 0 {
+				t.cond.Broadcast()
+			}
+			t.mu.Unlock()
+		})
+	}
+}
+
+// InFlight returns the number of work units currently in flight.
+func (t *IdleTracker) InFlight() int {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.n
+}
+
+// WaitIdle blocks until no work units are in flight, or ctx is done,
+// whichever comes first.
+func (t *IdleTracker) WaitIdle(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		t.mu.Lock()
+		t.cond.Broadcast()
+		t.mu.Unlock()
+	})
+	defer stop()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for t.n > 0 && ctx.Err() == nil {
+		t.cond.Wait()
+	}
+	return ctx.Err()
+}
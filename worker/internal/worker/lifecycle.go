@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Lifecycle statuses. A phase event carries one of these; the final event
+// for a task carries the aggregate across every phase that ran.
+const (
+	StatusStarted   = "started"
+	StatusCompleted = "completed"
+	StatusPartial   = "partial"
+	StatusAborted   = "aborted"
+	StatusFailed    = "failed"
+)
+
+// severity ranks statuses so the aggregate final status can be computed as
+// the worst status seen across every phase that ran.
+var severity = map[string]int{
+	StatusCompleted: 0,
+	StatusPartial:   1,
+	StatusAborted:   2,
+	StatusFailed:    3,
+}
+
+// WorseStatus returns whichever of a, b ranks worse, where
+// completed < partial < aborted < failed. It's used to fold per-phase
+// outcomes into a single aggregate status for the task.
+func WorseStatus(a, b string) string {
+	if severity[b] > severity[a] {
+		return b
+	}
+	return a
+}
+
+// Phase names used with ReportPhase.
+const (
+	PhaseResolvePackage    = "resolve-package"
+	PhaseStatic            = "static"
+	PhaseDynamic           = "dynamic"
+	PhaseDynamicInstall    = "dynamic-install"
+	PhaseDynamicImport     = "dynamic-import"
+	PhaseDynamicFunctional = "dynamic-functional"
+)
+
+// TaskContext identifies the analysis run that a series of lifecycle events
+// belongs to, so callers don't need to repeat the task/package identity on
+// every ReportPhase call.
+type TaskContext struct {
+	TaskID    string
+	Ecosystem string
+	Name      string
+	Version   string
+	Attempt   int
+}
+
+func (tc TaskContext) event(phase, status string) Event {
+	return Event{
+		TaskID:    tc.TaskID,
+		Ecosystem: tc.Ecosystem,
+		Name:      tc.Name,
+		Version:   tc.Version,
+		Phase:     phase,
+		Status:    status,
+		Timestamp: time.Now(),
+		Attempt:   tc.Attempt,
+	}
+}
+
+// ReportStarted signals that analysis of the task has begun.
+func ReportStarted(ctx context.Context, r CompletionReporter, tc TaskContext) error {
+	return deliver(ctx, r, tc.event("", StatusStarted))
+}
+
+// ReportPhase signals that a phase of analysis (see the Phase* constants)
+// has reached status, optionally attaching metadata such as per-bucket
+// upload byte counts.
+func ReportPhase(ctx context.Context, r CompletionReporter, tc TaskContext, phase, status string, metadata map[string]any) error {
+	event := tc.event(phase, status)
+	event.Metadata = metadata
+	return deliver(ctx, r, event)
+}
+
+// ReportFinished signals the final aggregate outcome of the task. cause, if
+// non-nil, populates the event's error class/message.
+func ReportFinished(ctx context.Context, r CompletionReporter, tc TaskContext, status string, cause error) error {
+	event := tc.event("", status)
+	if cause != nil {
+		event.ErrorClass = fmt.Sprintf("%T", cause)
+		event.ErrorMessage = cause.Error()
+	}
+	return deliver(ctx, r, event)
+}
+
+// deliver logs event via slog regardless of backend, so the lifecycle
+// signal is observable even when the analyzer has no task ID or reporter
+// backend configured, then forwards it to r.
+func deliver(ctx context.Context, r CompletionReporter, event Event) error {
+	slog.InfoContext(ctx, "Lifecycle event",
+		"task_id", event.TaskID,
+		"ecosystem", event.Ecosystem,
+		"name", event.Name,
+		"version", event.Version,
+		"phase", event.Phase,
+		"status", event.Status,
+		"attempt", event.Attempt,
+		"error_class", event.ErrorClass,
+		"error_message", event.ErrorMessage,
+	)
+
+	if r == nil {
+		return nil
+	}
+	return r.Report(ctx, event)
+}
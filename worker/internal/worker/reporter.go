@@ -0,0 +1,126 @@
+// Package worker implements reporters that signal analysis completion to
+// external backends (HTTP callback, Redis Streams, Kinesis, Kafka, ...).
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Event is a single progress/lifecycle signal emitted by the analyzer, from
+// the initial "started" event through per-phase updates to the final
+// aggregate outcome. Backends are free to serialize it however suits their
+// transport (e.g. the HTTP backend marshals it to JSON, the Redis backend
+// maps its fields onto stream entry values).
+type Event struct {
+	TaskID    string    `json:"task_id"`
+	Ecosystem string    `json:"ecosystem,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Version   string    `json:"version,omitempty"`
+	Phase     string    `json:"phase,omitempty"`
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+	Attempt   int       `json:"attempt,omitempty"`
+
+	// ErrorClass/ErrorMessage are populated when Status reflects a failure.
+	ErrorClass   string `json:"error_class,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+
+	// Metadata carries phase-specific extras, e.g. bytes uploaded per
+	// result-store bucket, that don't warrant a dedicated field.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// CompletionReporter delivers completion events for an analysis task to a
+// backend. Implementations must be safe to call from a single goroutine at
+// a time; callers that need concurrency should serialize their own calls.
+type CompletionReporter interface {
+	// Report delivers a single event. Implementations should retry
+	// transient failures internally rather than pushing that burden onto
+	// callers.
+	Report(ctx context.Context, event Event) error
+}
+
+// ReporterBackend selects which CompletionReporter implementation
+// NewCompletionReporter constructs.
+type ReporterBackend string
+
+const (
+	ReporterHTTP    ReporterBackend = "http"
+	ReporterRedis   ReporterBackend = "redis"
+	ReporterKinesis ReporterBackend = "kinesis"
+	ReporterKafka   ReporterBackend = "kafka"
+	ReporterNone    ReporterBackend = "none"
+)
+
+// ReporterConfig bundles the per-backend settings needed by
+// NewCompletionReporter. Only the fields relevant to the selected Backend
+// need to be populated.
+type ReporterConfig struct {
+	Backend ReporterBackend
+
+	// HTTP backend.
+	HTTP HTTPReporterConfig
+
+	// Redis Streams backend.
+	Redis RedisReporterConfig
+
+	// Kinesis backend.
+	Kinesis KinesisReporterConfig
+
+	// Kafka backend.
+	Kafka KafkaReporterConfig
+
+	// Additional backends to fan events out to alongside the primary one
+	// selected by Backend. Useful for mirroring events to a stream for
+	// audit while keeping the HTTP callback as the backend of record.
+	Mirror []ReporterConfig
+}
+
+// NewCompletionReporter builds the CompletionReporter selected by
+// cfg.Backend, wrapping it with the mirrors in cfg.Mirror when present.
+func NewCompletionReporter(cfg ReporterConfig) (CompletionReporter, error) {
+	primary, err := newSingleReporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Mirror) == 0 {
+		return primary, nil
+	}
+
+	reporters := []CompletionReporter{primary}
+	for _, mirrorCfg := range cfg.Mirror {
+		mirror, err := newSingleReporter(mirrorCfg)
+		if err != nil {
+			return nil, fmt.Errorf("building mirror reporter %q: %w", mirrorCfg.Backend, err)
+		}
+		reporters = append(reporters, mirror)
+	}
+
+	return NewMultiReporter(reporters...), nil
+}
+
+func newSingleReporter(cfg ReporterConfig) (CompletionReporter, error) {
+	switch cfg.Backend {
+	case ReporterHTTP, "":
+		return NewHTTPReporter(cfg.HTTP)
+	case ReporterRedis:
+		return NewRedisReporter(cfg.Redis)
+	case ReporterKinesis:
+		return NewKinesisReporter(cfg.Kinesis)
+	case ReporterKafka:
+		return NewKafkaReporter(cfg.Kafka)
+	case ReporterNone:
+		return NoopReporter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown reporter backend %q", cfg.Backend)
+	}
+}
+
+// NoopReporter discards every event. It backs the "none" backend, used when
+// an operator doesn't want completion signaling at all.
+type NoopReporter struct{}
+
+func (NoopReporter) Report(context.Context, Event) error { return nil }
@@ -0,0 +1,262 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ossf/package-analysis/internal/useragent"
+)
+
+// MTLSConfig configures an optional client certificate for the completion
+// reporter callback.
+type MTLSConfig struct {
+	// CertFile/KeyFile are the PEM-encoded client certificate and private
+	// key presented to the backend.
+	CertFile string
+	KeyFile  string
+	// CAFile, if set, overrides the system root CA pool with a single
+	// PEM-encoded CA used to verify the backend's certificate.
+	CAFile string
+}
+
+func (c MTLSConfig) enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
+func (c MTLSConfig) tlsConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", c.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	return tlsCfg, nil
+}
+
+// HTTPReporterConfig configures HTTPReporter.
+type HTTPReporterConfig struct {
+	// APIURL is the callback endpoint, e.g.
+	// http://backend-service.packamal.svc.cluster.local:8000/api/internal/callback/
+	APIURL string
+	// AuthToken is sent as a bearer token in the Authorization header.
+	AuthToken string
+
+	// TaskID/Ecosystem identify the task being reported on, and are folded
+	// into the reporter's User-Agent string for backend-side attribution.
+	TaskID    string
+	Ecosystem string
+
+	// MTLS configures an optional client certificate for the callback.
+	MTLS MTLSConfig
+
+	// Transport overrides the RoundTripper used for outgoing requests. If
+	// nil, http.DefaultTransport is used, or with MTLS configured a clone of
+	// it carrying the TLS settings (a plain *http.Transport when
+	// http.DefaultTransport is not one); either way it is wrapped with a
+	// reporter-specific User-Agent.
+	Transport http.RoundTripper
+
+	// RetryPolicy overrides the default retry backoff. Zero value means
+	// DefaultRetryPolicy().
+	RetryPolicy RetryPolicy
+
+	// Breaker overrides the default circuit breaker. Nil means a breaker
+	// that opens after 5 consecutive failures within a minute and probes
+	// again after 30s.
+	Breaker *CircuitBreaker
+
+	// Idle, if set, has each retry attempt registered as an in-flight work
+	// unit, so a shutdown handler can wait for outstanding reporter
+	// retries to settle via Idle.WaitIdle.
+	Idle *IdleTracker
+}
+
+// HTTPReporter posts completion events to a backend HTTP callback. Failed
+// requests are retried using a decorrelated-jitter backoff (see
+// RetryPolicy) that honors Retry-After on 429/503 responses, and a
+// CircuitBreaker short-circuits retries entirely once the backend looks
+// consistently down. A single *http.Client (and therefore its connection
+// pool) is shared across every attempt and every call to Report.
+type HTTPReporter struct {
+	apiURL      string
+	authToken   string
+	client      *http.Client
+	retryPolicy RetryPolicy
+	breaker     *CircuitBreaker
+	idle        *IdleTracker
+}
+
+// NewHTTPReporter creates a reporter that POSTs events to cfg.APIURL. If
+// cfg.APIURL is empty, the returned reporter no-ops.
+func NewHTTPReporter(cfg HTTPReporterConfig) (*HTTPReporter, error) {
+	retryPolicy := cfg.RetryPolicy
+	if retryPolicy == (RetryPolicy{}) {
+		retryPolicy = DefaultRetryPolicy()
+	}
+
+	breaker := cfg.Breaker
+	if breaker == nil {
+		breaker = NewCircuitBreaker(5, time.Minute, 30*time.Second)
+	}
+
+	transport := cfg.Transport
+	if transport == nil {
+		base := http.DefaultTransport
+		if cfg.MTLS.enabled() {
+			tlsCfg, err := cfg.MTLS.tlsConfig()
+			if err != nil {
+				return nil, fmt.Errorf("configuring mTLS for completion reporter: %w", err)
+			}
+			httpTransport := &http.Transport{Proxy: http.ProxyFromEnvironment, ForceAttemptHTTP2: true}
+			if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+				httpTransport = defaultTransport.Clone()
+			}
+			httpTransport.TLSClientConfig = tlsCfg
+			base = httpTransport
+		}
+		transport = useragent.DefaultRoundTripper(base, useragent.ReporterUserAgent(cfg.TaskID, cfg.Ecosystem))
+	}
+
+	return &HTTPReporter{
+		apiURL:      cfg.APIURL,
+		authToken:   cfg.AuthToken,
+		client:      &http.Client{Timeout: 15 * time.Second, Transport: transport},
+		retryPolicy: retryPolicy,
+		breaker:     breaker,
+		idle:        cfg.Idle,
+	}, nil
+}
+
+// Report sends a single event to the backend, retrying transient failures
+// per r.retryPolicy.
+func (r *HTTPReporter) Report(ctx context.Context, event Event) error {
+	if r.apiURL == "" {
+		slog.DebugContext(ctx, "No API URL configured, skipping backend notification")
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	clock := r.retryPolicy.clock()
+	start := clock.Now()
+	maxAttempts := r.retryPolicy.maxAttempts()
+	var delay time.Duration
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if !r.breaker.Allow() {
+			return fmt.Errorf("completion reporter circuit breaker open, not sending to %s", r.apiURL)
+		}
+
+		end := r.idle.Begin()
+		retryAfter, err := r.send(ctx, body)
+		end()
+		if err == nil {
+			r.breaker.RecordSuccess()
+			slog.InfoContext(ctx, "Successfully notified backend", "task_id", event.TaskID, "status", event.Status)
+			return nil
+		}
+		r.breaker.RecordFailure(err)
+
+		if errors.Is(err, errNotRetryable) {
+			return err
+		}
+
+		if r.retryPolicy.MaxTotalElapsed > 0 && clock.Now().Sub(start) >= r.retryPolicy.MaxTotalElapsed {
+			return fmt.Errorf("giving up after %s: %w", clock.Now().Sub(start), err)
+		}
+
+		if attempt == maxAttempts {
+			break
+		}
+
+		if retryAfter > 0 {
+			delay = retryAfter
+		} else {
+			delay = r.retryPolicy.nextDelay(delay)
+		}
+
+		slog.WarnContext(ctx, "Backend notification failed, retrying...",
+			"attempt", attempt,
+			"error", err,
+			"next_retry_in", delay,
+		)
+
+		select {
+		case <-time.After(delay):
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("failed to notify backend after %d attempts", maxAttempts)
+}
+
+// Healthz returns a snapshot of the reporter's circuit breaker state, for
+// exposing via a /healthz-style endpoint or metric.
+func (r *HTTPReporter) Healthz() BreakerStatus {
+	return r.breaker.Status()
+}
+
+// errNotRetryable marks a send error that Report returns at once instead of
+// retrying.
+var errNotRetryable = errors.New("not retryable")
+
+// send performs the actual HTTP POST request, returning a non-zero
+// retryAfter when the backend asked us to wait via a Retry-After header.
+func (r *HTTPReporter) send(ctx context.Context, body []byte) (retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", r.apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+r.authToken)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Consider 5xx errors and 429 (Too Many Requests) as retryable.
+	if resp.StatusCode >= 500 || resp.StatusCode == 429 {
+		retryAfter, _ := retryAfterDelay(resp, time.Now())
+		return retryAfter, fmt.Errorf("backend returned retryable error: %d", resp.StatusCode)
+	}
+
+	// 4xx errors (except 429) mean our request is wrong; don't retry.
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("backend rejected request with status: %d: %w", resp.StatusCode, errNotRetryable)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,149 @@
+package worker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Initial:     time.Millisecond,
+		Max:         5 * time.Millisecond,
+		Multiplier:  3,
+		MaxAttempts: 5,
+	}
+}
+
+func TestHTTPReporterRetriesOnRetryAfter(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	r, err := NewHTTPReporter(HTTPReporterConfig{APIURL: srv.URL, RetryPolicy: fastRetryPolicy()})
+	if err != nil {
+		t.Fatalf("NewHTTPReporter() error = %v", err)
+	}
+
+	if err := r.Report(context.Background(), Event{TaskID: "t1", Status: StatusStarted, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("server saw %d attempts, want 2", got)
+	}
+}
+
+func TestHTTPReporterRetriesOnServiceUnavailable(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	r, err := NewHTTPReporter(HTTPReporterConfig{APIURL: srv.URL, RetryPolicy: fastRetryPolicy()})
+	if err != nil {
+		t.Fatalf("NewHTTPReporter() error = %v", err)
+	}
+
+	if err := r.Report(context.Background(), Event{TaskID: "t1", Status: StatusStarted, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("server saw %d attempts, want 3", got)
+	}
+}
+
+func TestHTTPReporterRetriesOnNetworkError(t *testing.T) {
+	// Bind a listener and close it immediately so the address refuses
+	// connections, simulating a network error on every attempt.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 2
+	r, err := NewHTTPReporter(HTTPReporterConfig{APIURL: "http://" + addr, RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("NewHTTPReporter() error = %v", err)
+	}
+
+	if err := r.Report(context.Background(), Event{TaskID: "t1", Status: StatusStarted, Timestamp: time.Now()}); err == nil {
+		t.Fatal("Report() error = nil, want error after exhausting retries against an unreachable backend")
+	}
+}
+
+// steppingClock advances its own Now() by step every time it's read,
+// letting a test deterministically exhaust RetryPolicy.MaxTotalElapsed
+// without actually sleeping in real time.
+type steppingClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.now = c.now.Add(c.step)
+	return c.now
+}
+func (c *steppingClock) Sleep(time.Duration) {}
+
+func TestHTTPReporterGivesUpAfterMaxTotalElapsed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	policy := fastRetryPolicy()
+	policy.MaxAttempts = 100
+	policy.MaxTotalElapsed = time.Minute
+	policy.Clock = &steppingClock{now: time.Now(), step: time.Minute}
+
+	r, err := NewHTTPReporter(HTTPReporterConfig{APIURL: srv.URL, RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("NewHTTPReporter() error = %v", err)
+	}
+
+	if err := r.Report(context.Background(), Event{TaskID: "t1", Status: StatusStarted, Timestamp: time.Now()}); err == nil {
+		t.Fatal("Report() error = nil, want error once MaxTotalElapsed is exceeded")
+	}
+}
+
+func TestHTTPReporterSucceedsOnFirstAttempt(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	r, err := NewHTTPReporter(HTTPReporterConfig{APIURL: srv.URL, RetryPolicy: fastRetryPolicy()})
+	if err != nil {
+		t.Fatalf("NewHTTPReporter() error = %v", err)
+	}
+
+	if err := r.Report(context.Background(), Event{TaskID: "t1", Status: StatusStarted, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("server saw %d attempts, want 1", got)
+	}
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// KafkaReporterConfig configures KafkaReporter.
+type KafkaReporterConfig struct {
+	// Brokers is the list of seed broker addresses, e.g. ["kafka:9092"].
+	Brokers []string
+	// Topic is the target Kafka topic.
+	Topic string
+	// BacklogSize bounds the number of events buffered in memory awaiting
+	// flush to Kafka. Defaults to 100.
+	BacklogSize int
+	// FlushInterval is how often buffered events are flushed. Defaults to 2s.
+	FlushInterval time.Duration
+}
+
+// KafkaReporter batches completion events and ships them to a Kafka topic,
+// using the same bounded-backlog-plus-background-flusher pattern as
+// KinesisReporter.
+type KafkaReporter struct {
+	writer  *kafka.Writer
+	batcher *streamBatcher
+}
+
+// NewKafkaReporter creates a reporter that batches events to cfg.Topic.
+// Call Start before use and Stop during shutdown to flush the backlog.
+func NewKafkaReporter(cfg KafkaReporterConfig) (*KafkaReporter, error) {
+	if cfg.Topic == "" {
+		return nil, fmt.Errorf("kafka reporter: topic is required")
+	}
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka reporter: at least one broker is required")
+	}
+
+	r := &KafkaReporter{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(cfg.Brokers...),
+			Topic:    cfg.Topic,
+			Balancer: &kafka.LeastBytes{},
+		},
+	}
+	r.batcher = newStreamBatcher(streamBatcherConfig{
+		BacklogSize:   cfg.BacklogSize,
+		FlushInterval: cfg.FlushInterval,
+	}, r.writeMessages)
+
+	return r, nil
+}
+
+// Report enqueues event for delivery; it never blocks on network I/O.
+func (r *KafkaReporter) Report(_ context.Context, event Event) error {
+	r.batcher.Enqueue(event)
+	return nil
+}
+
+// Start launches the background flusher.
+func (r *KafkaReporter) Start(ctx context.Context) { r.batcher.Start(ctx) }
+
+// Stop flushes any remaining backlog, stops the background flusher, and
+// closes the underlying writer.
+func (r *KafkaReporter) Stop(ctx context.Context) error {
+	err := r.batcher.Stop(ctx)
+	if closeErr := r.writer.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (r *KafkaReporter) writeMessages(ctx context.Context, events []Event) error {
+	messages := make([]kafka.Message, 0, len(events))
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("marshal event: %w", err)
+		}
+		messages = append(messages, kafka.Message{
+			Key:   []byte(event.TaskID),
+			Value: data,
+		})
+	}
+
+	if err := r.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("kafka WriteMessages: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
+)
+
+// KinesisReporterConfig configures KinesisReporter.
+type KinesisReporterConfig struct {
+	// StreamName is the target Kinesis stream.
+	StreamName string
+	// BacklogSize bounds the number of events buffered in memory awaiting
+	// flush to Kinesis. Defaults to 100.
+	BacklogSize int
+	// FlushInterval is how often buffered events are flushed. Defaults to 2s.
+	FlushInterval time.Duration
+}
+
+// KinesisReporter batches completion events and ships them to a Kinesis
+// data stream via PutRecords, so the analyzer can enqueue events
+// non-blockingly, with Stop flushing any remaining backlog at shutdown.
+type KinesisReporter struct {
+	streamName string
+	client     *kinesis.Client
+	batcher    *streamBatcher
+}
+
+// NewKinesisReporter creates a reporter that batches events to cfg.StreamName.
+// Call Start before use and Stop during shutdown to flush the backlog.
+func NewKinesisReporter(cfg KinesisReporterConfig) (*KinesisReporter, error) {
+	if cfg.StreamName == "" {
+		return nil, fmt.Errorf("kinesis reporter: stream name is required")
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("kinesis reporter: loading AWS config: %w", err)
+	}
+
+	r := &KinesisReporter{
+		streamName: cfg.StreamName,
+		client:     kinesis.NewFromConfig(awsCfg),
+	}
+	r.batcher = newStreamBatcher(streamBatcherConfig{
+		BacklogSize:   cfg.BacklogSize,
+		FlushInterval: cfg.FlushInterval,
+	}, r.putRecords)
+
+	return r, nil
+}
+
+// Report enqueues event for delivery; it never blocks on network I/O.
+func (r *KinesisReporter) Report(_ context.Context, event Event) error {
+	r.batcher.Enqueue(event)
+	return nil
+}
+
+// Start launches the background flusher.
+func (r *KinesisReporter) Start(ctx context.Context) { r.batcher.Start(ctx) }
+
+// Stop flushes any remaining backlog and stops the background flusher.
+func (r *KinesisReporter) Stop(ctx context.Context) error { return r.batcher.Stop(ctx) }
+
+func (r *KinesisReporter) putRecords(ctx context.Context, events []Event) error {
+	entries := make([]types.PutRecordsRequestEntry, 0, len(events))
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("marshal event: %w", err)
+		}
+		entries = append(entries, types.PutRecordsRequestEntry{
+			Data:         data,
+			PartitionKey: aws.String(event.TaskID),
+		})
+	}
+
+	_, err := r.client.PutRecords(ctx, &kinesis.PutRecordsInput{
+		StreamName: aws.String(r.streamName),
+		Records:    entries,
+	})
+	if err != nil {
+		return fmt.Errorf("kinesis PutRecords: %w", err)
+	}
+
+	return nil
+}
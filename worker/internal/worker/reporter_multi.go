@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"errors"
+)
+
+// MultiReporter fans each event out to a list of reporters, e.g. to mirror
+// events to both an HTTP callback and a stream for audit. Report returns a
+// joined error of every backend failure, but still attempts delivery to
+// every reporter rather than stopping at the first failure.
+type MultiReporter struct {
+	reporters []CompletionReporter
+}
+
+// NewMultiReporter builds a CompletionReporter that forwards every event to
+// each of reporters in order.
+func NewMultiReporter(reporters ...CompletionReporter) *MultiReporter {
+	return &MultiReporter{reporters: reporters}
+}
+
+// Report delivers event to every backing reporter, collecting any errors.
+func (m *MultiReporter) Report(ctx context.Context, event Event) error {
+	var errs []error
+	for _, reporter := range m.reporters {
+		if err := reporter.Report(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start starts any backing reporter that has a Start(context.Context) method, such
+// as the Kinesis and Kafka backends.
+func (m *MultiReporter) Start(ctx context.Context) {
+	for _, reporter := range m.reporters {
+		if starter, ok := reporter.(interface{ Start(context.Context) }); ok {
+			starter.Start(ctx)
+		}
+	}
+}
+
+// Stop stops any backing reporter that has a Stop(context.Context) error method, flushing
+// their backlogs, and returns a joined error of any failures.
+func (m *MultiReporter) Stop(ctx context.Context) error {
+	var errs []error
+	for _, reporter := range m.reporters {
+		if stopper, ok := reporter.(interface{ Stop(context.Context) error }); ok {
+			if err := stopper.Stop(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisReporterConfig configures RedisReporter.
+type RedisReporterConfig struct {
+	// Addr is the redis server address, e.g. "localhost:6379".
+	Addr string
+	// Password authenticates to the redis server, if required.
+	Password string
+	// DB selects the redis logical database.
+	DB int
+	// StreamPrefix is prepended to "task:<id>:events" when the prefix is
+	// non-empty, yielding "<prefix>task:<id>:events".
+	StreamPrefix string
+}
+
+// RedisReporter publishes completion events onto a per-task Redis Stream,
+// `task:<id>:events`, via XADD. Consumers can tail the stream with XREAD or
+// form a consumer group for at-least-once delivery.
+type RedisReporter struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisReporter creates a reporter that XADDs events to
+// "task:<id>:events" on the redis server described by cfg.
+func NewRedisReporter(cfg RedisReporterConfig) (*RedisReporter, error) {
+	if cfg.Addr == "" {
+		return nil, fmt.Errorf("redis reporter: addr is required")
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	return &RedisReporter{client: client, prefix: cfg.StreamPrefix}, nil
+}
+
+// Report XADDs event onto the task's stream, with every Event field
+// preserved as a stream value (metadata is JSON-encoded, since XADD values
+// must be scalars) so consumers see the same structured payload as the
+// HTTP/Kinesis/Kafka backends.
+func (r *RedisReporter) Report(ctx context.Context, event Event) error {
+	stream := fmt.Sprintf("%stask:%s:events", r.prefix, event.TaskID)
+
+	values := map[string]any{
+		"task_id":       event.TaskID,
+		"ecosystem":     event.Ecosystem,
+		"name":          event.Name,
+		"version":       event.Version,
+		"phase":         event.Phase,
+		"status":        event.Status,
+		"timestamp":     event.Timestamp.UnixMilli(),
+		"attempt":       event.Attempt,
+		"error_class":   event.ErrorClass,
+		"error_message": event.ErrorMessage,
+	}
+
+	if len(event.Metadata) > 0 {
+		metadata, err := json.Marshal(event.Metadata)
+		if err != nil {
+			return fmt.Errorf("redis reporter: marshal metadata: %w", err)
+		}
+		values["metadata"] = string(metadata)
+	}
+
+	if err := r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		ID:     "*",
+		Values: values,
+	}).Err(); err != nil {
+		return fmt.Errorf("redis reporter: XADD %s: %w", stream, err)
+	}
+
+	return nil
+}
+
+// Stop releases the underlying redis connection pool. It satisfies the
+// same Stop(context.Context) error lifecycle interface as the Kinesis and
+// Kafka backends, so the factory's shutdown wiring (main.go, MultiReporter)
+// closes it without special-casing Redis.
+func (r *RedisReporter) Stop(context.Context) error {
+	return r.client.Close()
+}
@@ -0,0 +1,149 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// streamBatcher batches events into a bounded backlog and flushes them to a
+// streaming backend (Kinesis, Kafka, ...) on a background goroutine. It lets
+// callers enqueue events without blocking on network I/O; Stop makes a
+// final flush of whatever is still in the backlog and returns its error.
+// Events dropped from a full backlog (see Enqueue) are not delivered.
+//
+// publish is called with each flushed batch; it should return an error if
+// any event in the batch could not be delivered, in which case the batch is
+// retried on the next flush tick.
+type streamBatcher struct {
+	publish       func(ctx context.Context, events []Event) error
+	flushInterval time.Duration
+	backlogSize   int
+
+	mu      sync.Mutex
+	backlog []Event
+
+	flushCh chan struct{}
+	doneCh  chan struct{}
+	wg      sync.WaitGroup
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// streamBatcherConfig is the shared configuration understood by
+// Kinesis/Kafka-style backends.
+type streamBatcherConfig struct {
+	// BacklogSize bounds the number of events buffered in memory awaiting
+	// flush. Once full, Enqueue drops the oldest event to make room,
+	// logging a warning, rather than blocking the analyzer.
+	BacklogSize int
+	// FlushInterval is how often the background flusher attempts to drain
+	// the backlog. Defaults to 2s.
+	FlushInterval time.Duration
+}
+
+func newStreamBatcher(cfg streamBatcherConfig, publish func(ctx context.Context, events []Event) error) *streamBatcher {
+	backlogSize := cfg.BacklogSize
+	if backlogSize <= 0 {
+		backlogSize = 100
+	}
+	flushInterval := cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = 2 * time.Second
+	}
+
+	return &streamBatcher{
+		publish:       publish,
+		flushInterval: flushInterval,
+		backlogSize:   backlogSize,
+		flushCh:       make(chan struct{}, 1),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// Start launches the background flusher goroutine. It is safe to call
+// multiple times; only the first call has an effect.
+func (b *streamBatcher) Start(ctx context.Context) {
+	b.startOnce.Do(func() {
+		b.wg.Add(1)
+		go b.run(ctx)
+	})
+}
+
+// Stop signals the flusher to drain the backlog and exit, blocking until it
+// has done so.
+func (b *streamBatcher) Stop(ctx context.Context) error {
+	var err error
+	b.stopOnce.Do(func() {
+		close(b.doneCh)
+		b.wg.Wait()
+		err = b.flush(ctx)
+	})
+	return err
+}
+
+// Enqueue adds event to the backlog without blocking. If the backlog is
+// full, the oldest buffered event is dropped to make room.
+func (b *streamBatcher) Enqueue(event Event) {
+	b.mu.Lock()
+	if len(b.backlog) >= b.backlogSize {
+		slog.Warn("stream reporter backlog full, dropping oldest event", "backlog_size", b.backlogSize)
+		b.backlog = b.backlog[1:]
+	}
+	b.backlog = append(b.backlog, event)
+	b.mu.Unlock()
+
+	select {
+	case b.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+func (b *streamBatcher) run(ctx context.Context) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := b.flush(ctx); err != nil {
+				slog.WarnContext(ctx, "stream reporter flush failed", "error", err)
+			}
+		case <-b.flushCh:
+			// Coalesce with the ticker; no need to flush immediately on
+			// every enqueue.
+		case <-b.doneCh:
+			return
+		}
+	}
+}
+
+func (b *streamBatcher) flush(ctx context.Context) error {
+	b.mu.Lock()
+	if len(b.backlog) == 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	batch := b.backlog
+	b.backlog = nil
+	b.mu.Unlock()
+
+	if err := b.publish(ctx, batch); err != nil {
+		// Put the batch back at the front of the backlog so it is retried
+		// on the next flush rather than silently dropped.
+		b.mu.Lock()
+		b.backlog = append(batch, b.backlog...)
+		if len(b.backlog) > b.backlogSize {
+			b.backlog = b.backlog[len(b.backlog)-b.backlogSize:]
+		}
+		b.mu.Unlock()
+		return fmt.Errorf("publishing batch of %d events: %w", len(batch), err)
+	}
+
+	return nil
+}
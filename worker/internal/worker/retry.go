@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Clock abstracts time so retry/breaker logic can be driven by a fake clock
+// in tests instead of wall-clock time.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// realClock is the default Clock, backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// RetryPolicy configures retry backoff for reporter backends that make
+// network calls. Delays follow AWS's decorrelated-jitter algorithm:
+// sleep = min(Max, random_between(Initial, prev*Multiplier)), which spreads
+// out retries from many concurrent callers far better than plain
+// exponential backoff.
+type RetryPolicy struct {
+	Initial         time.Duration
+	Max             time.Duration
+	Multiplier      float64
+	MaxAttempts     int
+	MaxTotalElapsed time.Duration
+
+	// Clock, if set, overrides time.Now when measuring MaxTotalElapsed.
+	// Defaults to realClock. The wait between attempts always runs on real
+	// time.
+	Clock Clock
+}
+
+// DefaultRetryPolicy returns the retry policy used by HTTPReporter unless
+// overridden.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Initial:         500 * time.Millisecond,
+		Max:             30 * time.Second,
+		Multiplier:      3,
+		MaxAttempts:     10,
+		MaxTotalElapsed: 5 * time.Minute,
+	}
+}
+
+func (p RetryPolicy) clock() Clock {
+	if p.Clock != nil {
+		return p.Clock
+	}
+	return realClock{}
+}
+
+func (p RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return 10
+	}
+	return p.MaxAttempts
+}
+
+// nextDelay computes the decorrelated-jitter delay for the next attempt,
+// given the delay used for the previous attempt (zero for the first
+// retry). Per AWS's algorithm, the first retry is drawn as though the
+// previous sleep were Initial, not zero, so it's jittered across
+// random_between(Initial, Initial*Multiplier) like every later retry
+// instead of always landing on exactly Initial.
+func (p RetryPolicy) nextDelay(prev time.Duration) time.Duration {
+	lo := p.Initial
+	if lo <= 0 {
+		lo = 500 * time.Millisecond
+	}
+	mult := p.Multiplier
+	if mult <= 0 {
+		mult = 3
+	}
+	if prev <= 0 {
+		prev = lo
+	}
+
+	hi := time.Duration(float64(prev) * mult)
+	if hi <= lo {
+		hi = lo + 1
+	}
+
+	d := lo + time.Duration(rand.Int63n(int64(hi-lo)))
+	if p.Max > 0 && d > p.Max {
+		d = p.Max
+	}
+	return d
+}
+
+// retryAfterDelay parses the Retry-After header from resp, honoring both
+// the delta-seconds and HTTP-date forms, and returns (0, false) if absent
+// or unparseable.
+func retryAfterDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var errTest = errors.New("test error")
+
+// fakeClock is a manually-advanced Clock for deterministic retry/breaker
+// tests. Sleep is a no-op; tests advance time explicitly via advance.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Sleep(time.Duration)     {}
+func (c *fakeClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestRetryPolicyNextDelayFirstAttemptIsJittered(t *testing.T) {
+	policy := RetryPolicy{Initial: 500 * time.Millisecond, Max: 30 * time.Second, Multiplier: 3}
+
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 50; i++ {
+		d := policy.nextDelay(0)
+		if d < policy.Initial || d > policy.Initial*3 {
+			t.Fatalf("nextDelay(0) = %s, want within [%s, %s]", d, policy.Initial, policy.Initial*3)
+		}
+		seen[d] = true
+	}
+	if len(seen) == 1 {
+		t.Fatalf("nextDelay(0) returned the same value %d times in a row; first retry is not jittered", 50)
+	}
+}
+
+func TestRetryPolicyNextDelayCapsAtMax(t *testing.T) {
+	policy := RetryPolicy{Initial: time.Second, Max: 2 * time.Second, Multiplier: 3}
+
+	prev := time.Duration(0)
+	for i := 0; i < 10; i++ {
+		prev = policy.nextDelay(prev)
+		if prev > policy.Max {
+			t.Fatalf("nextDelay returned %s, want <= Max %s", prev, policy.Max)
+		}
+	}
+}
+
+func TestRetryAfterDelayDeltaSeconds(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	d, ok := retryAfterDelay(resp, time.Now())
+	if !ok || d != 5*time.Second {
+		t.Fatalf("retryAfterDelay() = (%s, %v), want (5s, true)", d, ok)
+	}
+}
+
+func TestRetryAfterDelayAbsent(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if _, ok := retryAfterDelay(resp, time.Now()); ok {
+		t.Fatal("retryAfterDelay() = ok, want !ok for missing header")
+	}
+}
+
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	b := NewCircuitBreaker(3, time.Minute, 10*time.Second)
+	b.Clock = clock
+
+	for i := 0; i < 2; i++ {
+		if !b.Allow() {
+			t.Fatalf("Allow() = false before threshold reached (failure %d)", i)
+		}
+		b.RecordFailure(errTest)
+	}
+	if !b.Allow() {
+		t.Fatal("Allow() = false, want true just below threshold")
+	}
+	b.RecordFailure(errTest)
+
+	if b.Allow() {
+		t.Fatal("Allow() = true, want false once breaker is open")
+	}
+
+	clock.advance(10 * time.Second)
+	if !b.Allow() {
+		t.Fatal("Allow() = false, want true (half-open probe) once OpenDuration has elapsed")
+	}
+
+	b.RecordSuccess()
+	if !b.Allow() {
+		t.Fatal("Allow() = false, want true after a successful half-open probe closes the breaker")
+	}
+}